@@ -0,0 +1,143 @@
+// Command mnemosyned runs the mnemosyne session manager daemon.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/piotrkowalczuk/mnemosyne/mnemosyned"
+	"github.com/piotrkowalczuk/sklog"
+)
+
+var (
+	flagStorage           = flag.String("storage", getStringEnvOr("MNEMOSYNED_STORAGE", mnemosyned.StorageEnginePostgres), "storage engine, one of: postgres, redis, in-memory")
+	flagRPCAddr           = flag.String("rpc.address", getStringEnvOr("MNEMOSYNED_RPC_ADDRESS", ":8080"), "tcp address the gRPC server listens on")
+	flagHTTPAddr          = flag.String("http.address", getStringEnvOr("MNEMOSYNED_HTTP_ADDRESS", ""), "tcp address the REST gateway listens on, disabled when empty")
+	flagDebugAddr         = flag.String("debug.address", getStringEnvOr("MNEMOSYNED_DEBUG_ADDRESS", ""), "tcp address the debug/health server listens on, disabled when empty")
+	flagPostgresAddress   = flag.String("postgres.address", getStringEnvOr("MNEMOSYNED_POSTGRES_ADDRESS", ""), "postgres connection string, required when storage is postgres")
+	flagRedisAddress      = flag.String("redis.address", getStringEnvOr("MNEMOSYNED_REDIS_ADDRESS", "127.0.0.1:6379"), "redis address, used when storage is redis")
+	flagRedisPassword     = flag.String("redis.password", getStringEnvOr("MNEMOSYNED_REDIS_PASSWORD", ""), "redis password, used when storage is redis")
+	flagRedisDB           = flag.Int("redis.db", 0, "redis database index, used when storage is redis")
+	flagRedisPoolSize     = flag.Int("redis.pool-size", 10, "redis connection pool size, used when storage is redis")
+	flagMonitoring        = flag.Bool("monitoring", false, "enable prometheus monitoring")
+	flagClusterListenAddr = flag.String("cluster.listen", getStringEnvOr("MNEMOSYNED_CLUSTER_LISTEN", ""), "address this node advertises to its peers, disabled when empty")
+	flagClusterSeeds      = flag.String("cluster.seeds", getStringEnvOr("MNEMOSYNED_CLUSTER_SEEDS", ""), "comma separated list of seed peer addresses")
+	flagClusterBindPort   = flag.Int("cluster.gossip-port", 0, "gossip bind port, enables memberlist-based membership when non-zero")
+	flagMinReadyPeers     = flag.Int("cluster.min-ready-peers", 0, "minimum number of live cluster peers required for /readyz to report ready")
+	flagAuthMode          = flag.String("auth.mode", getStringEnvOr("MNEMOSYNED_AUTH_MODE", ""), "authentication mode, one of: jwt, shared-secret, disabled when empty")
+	flagAuthJWTPublicKey  = flag.String("auth.jwt.public-key-file", getStringEnvOr("MNEMOSYNED_AUTH_JWT_PUBLIC_KEY_FILE", ""), "path to the PEM-encoded public key used to verify JWTs")
+	flagAuthJWTIssuer     = flag.String("auth.jwt.issuer", getStringEnvOr("MNEMOSYNED_AUTH_JWT_ISSUER", ""), "required issuer claim for JWTs")
+	flagAuthJWTAudience   = flag.String("auth.jwt.audience", getStringEnvOr("MNEMOSYNED_AUTH_JWT_AUDIENCE", ""), "required audience claim for JWTs")
+	flagAuthSharedSecret  = flag.String("auth.shared-secret", getStringEnvOr("MNEMOSYNED_AUTH_SHARED_SECRET", ""), "shared secret expected in the authorization header")
+	flagShutdownTimeout   = flag.Duration("shutdown.timeout", mnemosyned.DefaultShutdownTimeout, "how long to wait for in-flight RPCs to drain before forcing an abrupt shutdown")
+)
+
+func main() {
+	flag.Parse()
+
+	logger := log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr))
+
+	rpcListener, err := net.Listen("tcp", *flagRPCAddr)
+	if err != nil {
+		sklog.Error(logger, fmt.Errorf("rpc listener setup failure: %w", err))
+		os.Exit(1)
+	}
+
+	opts := &mnemosyned.DaemonOpts{
+		Monitoring:           *flagMonitoring,
+		Storage:              *flagStorage,
+		PostgresAddress:      *flagPostgresAddress,
+		RedisAddress:         *flagRedisAddress,
+		RedisPassword:        *flagRedisPassword,
+		RedisDB:              *flagRedisDB,
+		RedisPoolSize:        *flagRedisPoolSize,
+		Logger:               logger,
+		RPCListener:          rpcListener,
+		ClusterListenAddr:    *flagClusterListenAddr,
+		ClusterSeeds:         splitAndTrim(*flagClusterSeeds),
+		ClusterBindPort:      *flagClusterBindPort,
+		AuthMode:             *flagAuthMode,
+		AuthJWTPublicKeyFile: *flagAuthJWTPublicKey,
+		AuthJWTIssuer:        *flagAuthJWTIssuer,
+		AuthJWTAudience:      *flagAuthJWTAudience,
+		AuthSharedSecret:     *flagAuthSharedSecret,
+		MinReadyPeers:        *flagMinReadyPeers,
+	}
+
+	if *flagHTTPAddr != "" {
+		httpListener, err := net.Listen("tcp", *flagHTTPAddr)
+		if err != nil {
+			sklog.Error(logger, fmt.Errorf("http listener setup failure: %w", err))
+			os.Exit(1)
+		}
+		opts.HTTPListener = httpListener
+	}
+	if *flagDebugAddr != "" {
+		debugListener, err := net.Listen("tcp", *flagDebugAddr)
+		if err != nil {
+			sklog.Error(logger, fmt.Errorf("debug listener setup failure: %w", err))
+			os.Exit(1)
+		}
+		opts.DebugListener = debugListener
+	}
+
+	d, err := mnemosyned.NewDaemon(opts)
+	if err != nil {
+		sklog.Error(logger, fmt.Errorf("daemon setup failure: %w", err))
+		os.Exit(1)
+	}
+	if err := d.Run(); err != nil {
+		sklog.Error(logger, fmt.Errorf("daemon start failure: %w", err))
+		os.Exit(1)
+	}
+
+	awaitShutdown(logger, d, *flagShutdownTimeout)
+}
+
+// awaitShutdown blocks until SIGINT or SIGTERM is received, then translates
+// it into a CloseWithContext bounded by shutdownTimeout so in-flight RPCs
+// get a chance to drain instead of being cut off by the process exiting.
+func awaitShutdown(logger log.Logger, d *mnemosyned.Daemon, shutdownTimeout time.Duration) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+
+	s := <-sig
+	sklog.Info(logger, "shutdown signal received", "signal", s.String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := d.CloseWithContext(ctx); err != nil {
+		sklog.Error(logger, err)
+		os.Exit(1)
+	}
+}
+
+func splitAndTrim(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func getStringEnvOr(env, or string) string {
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	return or
+}
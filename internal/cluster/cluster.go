@@ -0,0 +1,265 @@
+// Package cluster implements access-token based routing across a set of
+// mnemosyned peers.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/piotrkowalczuk/mnemosyne/mnemosynerpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// DefaultReplicas is the number of virtual nodes that a single physical node
+// contributes to the consistent-hash ring when Opts.Replicas is not set.
+const DefaultReplicas = 128
+
+// Node represents single member of the cluster, as seen from the local node.
+type Node struct {
+	Addr   string
+	Client mnemosynerpc.SessionManagerClient
+
+	conn *grpc.ClientConn
+}
+
+// Opts is a constructor argument that can be passed to the New function.
+type Opts struct {
+	Listen   string
+	Seeds    []string
+	Replicas int
+	Logger   *zap.Logger
+
+	// BindPort, when non-zero, enables gossip-based membership: the cluster
+	// joins a SWIM protocol group via memberlist, bound on BindPort, using
+	// Seeds as initial gossip contacts. When zero, Seeds is treated as a
+	// static membership list, as it was before gossip support existed.
+	BindPort int
+}
+
+type vnode struct {
+	hash uint64
+	addr string
+}
+
+// Cluster keeps track of every known peer and routes access tokens to their
+// owning node using a consistent-hash ring, so that membership changes move
+// only a small fraction of keys.
+type Cluster struct {
+	mu       sync.RWMutex
+	listen   string
+	replicas int
+	logger   *zap.Logger
+
+	nodes map[string]*Node
+	ring  []vnode
+
+	gossip *gossip
+}
+
+// New allocates a Cluster seeded with the given listen address and peers.
+func New(opts Opts) (*Cluster, error) {
+	if opts.Listen == "" {
+		return nil, fmt.Errorf("cluster: listen address is required")
+	}
+	if opts.Replicas <= 0 {
+		opts.Replicas = DefaultReplicas
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	c := &Cluster{
+		listen:   opts.Listen,
+		replicas: opts.Replicas,
+		logger:   logger,
+		nodes:    make(map[string]*Node),
+	}
+
+	addrs := make([]string, 0, len(opts.Seeds)+1)
+	addrs = append(addrs, opts.Listen)
+	if opts.BindPort == 0 {
+		addrs = append(addrs, opts.Seeds...)
+	}
+	sort.Strings(addrs)
+
+	seen := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		if seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		c.AddNode(addr)
+	}
+
+	if opts.BindPort != 0 {
+		g, err := newGossip(c, opts.Listen, opts.Seeds, opts.BindPort, logger)
+		if err != nil {
+			return nil, fmt.Errorf("cluster: gossip setup failed: %w", err)
+		}
+		c.gossip = g
+	}
+
+	return c, nil
+}
+
+// Shutdown leaves the gossip group, if one was joined, so that other members
+// stop waiting for this node's failure detector to time out.
+func (c *Cluster) Shutdown() error {
+	if c.gossip == nil {
+		return nil
+	}
+	return c.gossip.shutdown()
+}
+
+// AddNode inserts addr, and its virtual nodes, into the ring. Calling it for
+// an address that is already a member is a no-op.
+func (c *Cluster) AddNode(addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.nodes[addr]; ok {
+		return
+	}
+	c.nodes[addr] = &Node{Addr: addr}
+
+	for i := 0; i < c.replicas; i++ {
+		c.ring = append(c.ring, vnode{
+			hash: hashKey(fmt.Sprintf("%s#%d", addr, i)),
+			addr: addr,
+		})
+	}
+	sort.Slice(c.ring, func(i, j int) bool { return c.ring[i].hash < c.ring[j].hash })
+
+	c.logger.Info("node added", zap.String("address", addr))
+}
+
+// RemoveNode drops addr, and its virtual nodes, from the ring.
+func (c *Cluster) RemoveNode(addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.nodes[addr]; !ok {
+		return
+	}
+	delete(c.nodes, addr)
+
+	ring := c.ring[:0]
+	for _, v := range c.ring {
+		if v.addr != addr {
+			ring = append(ring, v)
+		}
+	}
+	c.ring = ring
+
+	c.logger.Info("node removed", zap.String("address", addr))
+}
+
+// Nodes returns every known member, sorted by address for deterministic iteration.
+func (c *Cluster) Nodes() []*Node {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	nodes := make([]*Node, 0, len(c.nodes))
+	for _, n := range c.nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Addr < nodes[j].Addr })
+	return nodes
+}
+
+// Len returns number of known physical nodes.
+func (c *Cluster) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.nodes)
+}
+
+// Listen returns the address the local node is listening on.
+func (c *Cluster) Listen() string {
+	return c.listen
+}
+
+// Get returns the node that owns token, walking the ring clockwise from
+// hash(token) and wrapping around when the end of the ring is reached.
+func (c *Cluster) Get(token string) (*Node, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.ring) == 0 {
+		return nil, false
+	}
+
+	h := hashKey(token)
+	i := sort.Search(len(c.ring), func(i int) bool { return c.ring[i].hash >= h })
+	if i == len(c.ring) {
+		i = 0
+	}
+	return c.nodes[c.ring[i].addr], true
+}
+
+// GetOther behaves like Get but never returns the local node, so that callers
+// can forward a request to the actual owner.
+func (c *Cluster) GetOther(token string) (*Node, bool) {
+	n, ok := c.Get(token)
+	if !ok || n.Addr == c.listen {
+		return nil, false
+	}
+	return n, true
+}
+
+// GetN returns up to n distinct physical owners of token, in ring order,
+// starting at its primary owner. It is the basis for future replication.
+func (c *Cluster) GetN(token string, n int) []*Node {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.ring) == 0 || n <= 0 {
+		return nil
+	}
+
+	h := hashKey(token)
+	start := sort.Search(len(c.ring), func(i int) bool { return c.ring[i].hash >= h })
+
+	out := make([]*Node, 0, n)
+	seen := make(map[string]bool, n)
+	for i := 0; i < len(c.ring) && len(out) < n; i++ {
+		v := c.ring[(start+i)%len(c.ring)]
+		if seen[v.addr] {
+			continue
+		}
+		seen[v.addr] = true
+		out = append(out, c.nodes[v.addr])
+	}
+	return out
+}
+
+// Connect dials every known peer other than the local node.
+func (c *Cluster) Connect(opts ...grpc.DialOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for addr, n := range c.nodes {
+		if addr == c.listen || n.conn != nil {
+			continue
+		}
+		conn, err := grpc.DialContext(context.Background(), addr, opts...)
+		if err != nil {
+			return fmt.Errorf("cluster: dial %s failed: %w", addr, err)
+		}
+		n.conn = conn
+		n.Client = mnemosynerpc.NewSessionManagerClient(conn)
+	}
+	return nil
+}
+
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64()
+}
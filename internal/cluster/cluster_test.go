@@ -9,8 +9,6 @@ import (
 
 	"go.uber.org/zap"
 
-	"strings"
-
 	_ "github.com/lib/pq"
 	"github.com/piotrkowalczuk/mnemosyne/internal/cluster"
 	"github.com/piotrkowalczuk/mnemosyne/mnemosyned"
@@ -79,23 +77,90 @@ func TestCluster_Get(t *testing.T) {
 		t.Fatalf("unexpected error: %s", err.Error())
 	}
 
-	nodes := append(seeds, listen)
-	sort.Strings(nodes)
-	for k, addr := range nodes {
-		got, ok := c.Get(int32(k))
+	// the same token must always resolve to the same node, regardless of how
+	// many times the ring is queried.
+	for i := 0; i < 100; i++ {
+		at := fmt.Sprintf("access-token-%d", i)
+		first, ok := c.Get(at)
 		if !ok {
-			t.Errorf("node not found: %s", addr)
-			continue
+			t.Fatalf("node not found for %s", at)
 		}
-		if strings.HasPrefix(addr, "10") {
-			continue
+		second, ok := c.Get(at)
+		if !ok || second.Addr != first.Addr {
+			t.Errorf("%s: owner is not stable across lookups", at)
 		}
-		if got.Addr != addr {
-			t.Errorf("address mismatch, expected %s but got %s", addr, got.Addr)
-		} else {
-			t.Logf("node under key %d and address %s passed", k, addr)
+	}
+}
+
+func TestCluster_GetN(t *testing.T) {
+	listen := "172.17.0.1"
+	seeds := []string{"172.17.0.2", "172.17.0.3", "10.10.0.1"}
+	c, err := cluster.New(cluster.Opts{
+		Listen: listen,
+		Seeds:  seeds,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	nodes := c.GetN("access-token-0", 3)
+	if len(nodes) != 3 {
+		t.Fatalf("expected 3 distinct owners, got %d", len(nodes))
+	}
+	seen := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		if seen[n.Addr] {
+			t.Errorf("owner %s returned more than once", n.Addr)
 		}
+		seen[n.Addr] = true
+	}
+}
+
+// TestCluster_KeyMovement asserts that the consistent-hash ring only reshuffles
+// roughly 1/N of the keys when a single node joins a cluster, ending up with N
+// nodes, instead of shuffling ownership wholesale like a plain sorted-index
+// lookup would.
+func TestCluster_KeyMovement(t *testing.T) {
+	listen := "172.17.0.1"
+	seeds := []string{"172.17.0.2", "172.17.0.3", "172.17.0.4", "172.17.0.5"}
+	c, err := cluster.New(cluster.Opts{
+		Listen: listen,
+		Seeds:  seeds,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	const nbKeys = 10000
+	before := make(map[string]string, nbKeys)
+	for i := 0; i < nbKeys; i++ {
+		at := fmt.Sprintf("access-token-%d", i)
+		n, ok := c.Get(at)
+		if !ok {
+			t.Fatalf("node not found for %s", at)
+		}
+		before[at] = n.Addr
+	}
+
+	c.AddNode("172.17.0.6")
+
+	var moved int
+	for at, addr := range before {
+		n, ok := c.Get(at)
+		if !ok {
+			t.Fatalf("node not found for %s", at)
+		}
+		if n.Addr != addr {
+			moved++
+		}
+	}
+
+	n := c.Len()
+	maxExpected := 2 * nbKeys / n
+	if moved > maxExpected {
+		t.Errorf("too many keys relocated on node join: %d out of %d (max expected %d)", moved, nbKeys, maxExpected)
 	}
+	t.Logf("%d/%d keys relocated after adding a 6th node", moved, nbKeys)
 }
 
 func TestCluster_GetOther(t *testing.T) {
@@ -0,0 +1,104 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"go.uber.org/zap"
+)
+
+const leaveTimeout = 5 * time.Second
+
+// gossip wraps a memberlist.Memberlist instance and feeds its membership
+// events into a Cluster's consistent-hash ring.
+type gossip struct {
+	list   *memberlist.Memberlist
+	logger *zap.Logger
+}
+
+// newGossip joins a SWIM gossip group seeded from seeds and drives c's ring
+// membership from the resulting NotifyJoin/NotifyLeave/NotifyUpdate events.
+// The local node's metadata carries grpcAddr, the address other peers should
+// dial to reach the local gRPC server, since the gossip bind address is not
+// necessarily the same as the gRPC listen address.
+func newGossip(c *Cluster, grpcAddr string, seeds []string, bindPort int, logger *zap.Logger) (*gossip, error) {
+	cfg := memberlist.DefaultLANConfig()
+	cfg.BindPort = bindPort
+	cfg.AdvertisePort = bindPort
+	cfg.Name = grpcAddr
+	cfg.Events = &eventDelegate{cluster: c, logger: logger}
+	cfg.Delegate = &metaDelegate{grpcAddr: grpcAddr}
+
+	list, err := memberlist.Create(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(seeds) > 0 {
+		if _, err := list.Join(seeds); err != nil {
+			logger.Warn("failed to join gossip seeds", zap.Strings("seeds", seeds), zap.Error(err))
+		}
+	}
+
+	return &gossip{list: list, logger: logger}, nil
+}
+
+func (g *gossip) shutdown() error {
+	if err := g.list.Leave(leaveTimeout); err != nil {
+		return err
+	}
+	return g.list.Shutdown()
+}
+
+// metaDelegate attaches the local node's gRPC address to its gossip metadata
+// so that peers can learn how to dial it, without needing the gossip bind
+// address and the gRPC listen address to match.
+type metaDelegate struct {
+	grpcAddr string
+}
+
+func (d *metaDelegate) NodeMeta(limit int) []byte {
+	b := []byte(d.grpcAddr)
+	if len(b) > limit {
+		b = b[:limit]
+	}
+	return b
+}
+
+func (d *metaDelegate) NotifyMsg([]byte)                           {}
+func (d *metaDelegate) GetBroadcasts(overhead, limit int) [][]byte { return nil }
+func (d *metaDelegate) LocalState(join bool) []byte                { return nil }
+func (d *metaDelegate) MergeRemoteState(buf []byte, join bool)     {}
+
+// eventDelegate drives the Cluster ring from memberlist membership events.
+type eventDelegate struct {
+	cluster *Cluster
+	logger  *zap.Logger
+}
+
+func (e *eventDelegate) NotifyJoin(n *memberlist.Node) {
+	addr := grpcAddrOf(n)
+	e.cluster.AddNode(addr)
+	e.logger.Info("peer joined", zap.String("gossip_name", n.Name), zap.String("grpc_address", addr))
+}
+
+func (e *eventDelegate) NotifyLeave(n *memberlist.Node) {
+	addr := grpcAddrOf(n)
+	e.cluster.RemoveNode(addr)
+	e.logger.Info("peer left", zap.String("gossip_name", n.Name), zap.String("grpc_address", addr))
+}
+
+// NotifyUpdate fires on metadata/state changes for a still-live node; removal
+// is handled exclusively by NotifyLeave, since memberlist routes dead/left
+// transitions through that callback rather than through NotifyUpdate.
+func (e *eventDelegate) NotifyUpdate(n *memberlist.Node) {
+	e.cluster.AddNode(grpcAddrOf(n))
+}
+
+// grpcAddrOf extracts the gRPC dial address carried in a gossip node's
+// metadata, falling back to its gossip name when metadata is unset.
+func grpcAddrOf(n *memberlist.Node) string {
+	if len(n.Meta) > 0 {
+		return string(n.Meta)
+	}
+	return n.Name
+}
@@ -0,0 +1,88 @@
+package cluster
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TestGossip_HardKillMarksNodeDead exercises the failure-detection path that
+// TestDaemon_ClusterGossip does not: it kills a peer's gossip transport
+// without ever broadcasting a Leave message, so survivors can only learn
+// about it through memberlist's suspicion timeout. memberlist reports that
+// as a dead-node transition through NotifyLeave, so the ring is expected to
+// reshard via the same callback a graceful departure would use.
+func TestGossip_HardKillMarksNodeDead(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test takes too long to run it in short mode")
+	}
+
+	logger := zap.NewNop()
+
+	p1, p2, p3 := freeGossipPort(t), freeGossipPort(t), freeGossipPort(t)
+	addrs := []string{
+		loopbackGossipAddr(p1),
+		loopbackGossipAddr(p2),
+		loopbackGossipAddr(p3),
+	}
+
+	newCluster := func(listen string, port int) *Cluster {
+		c, err := New(Opts{
+			Listen:   listen,
+			Seeds:    addrs,
+			BindPort: port,
+			Logger:   logger,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		return c
+	}
+
+	c1 := newCluster(addrs[0], p1)
+	defer c1.Shutdown()
+	c2 := newCluster(addrs[1], p2)
+	defer c2.Shutdown()
+	c3 := newCluster(addrs[2], p3)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for c1.Len() != 3 || c2.Len() != 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("cluster did not converge on 3 nodes in time: c1=%d c2=%d", c1.Len(), c2.Len())
+		}
+		<-time.After(100 * time.Millisecond)
+	}
+
+	// Kill c3's gossip transport outright, bypassing gossip.shutdown, so no
+	// Leave message is ever broadcast: c1 and c2 must rely on memberlist's
+	// suspicion timeout to notice the failure.
+	if err := c3.gossip.list.Shutdown(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	deadline = time.Now().Add(30 * time.Second)
+	for c1.Len() != 2 || c2.Len() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("cluster did not reshard after a hard node failure: c1=%d c2=%d", c1.Len(), c2.Len())
+		}
+		<-time.After(100 * time.Millisecond)
+	}
+}
+
+func freeGossipPort(t testing.TB) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func loopbackGossipAddr(port int) string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+}
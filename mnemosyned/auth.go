@@ -0,0 +1,237 @@
+package mnemosyned
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/subtle"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Auth modes accepted by DaemonOpts.AuthMode.
+const (
+	AuthModeNone         = "none"
+	AuthModeJWT          = "jwt"
+	AuthModeSharedSecret = "shared-secret"
+)
+
+// ErrVerifyOnly wraps verification failures (bad signature, expired token,
+// wrong issuer/audience, missing/malformed credentials) so that callers can
+// use errors.Is to tell them apart from transport-level errors.
+var ErrVerifyOnly = errors.New("mnemosyned: credential verification failed")
+
+type claimsContextKey struct{}
+
+// Claims carries the verified identity attached to the context of an
+// authenticated request.
+type Claims struct {
+	Subject string
+	Scopes  []string
+}
+
+// ClaimsFromContext returns the Claims attached by the auth interceptor, if any.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	c, ok := ctx.Value(claimsContextKey{}).(*Claims)
+	return c, ok
+}
+
+// authenticator verifies the bearer credential carried by an incoming
+// request's metadata and, on success, returns the context enriched with Claims.
+type authenticator interface {
+	authenticate(ctx context.Context) (context.Context, error)
+}
+
+// initAuthenticator builds the authenticator configured by opts, or nil when
+// AuthMode is empty or AuthModeNone.
+func initAuthenticator(opts *DaemonOpts) (authenticator, error) {
+	switch opts.AuthMode {
+	case "", AuthModeNone:
+		return nil, nil
+	case AuthModeSharedSecret:
+		if opts.AuthSharedSecret == "" {
+			return nil, errors.New("mnemosyned: AuthSharedSecret is required for shared-secret auth mode")
+		}
+		return &sharedSecretAuthenticator{secret: opts.AuthSharedSecret}, nil
+	case AuthModeJWT:
+		key, err := loadJWTPublicKey(opts.AuthJWTPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &jwtAuthenticator{
+			key:      key,
+			issuer:   opts.AuthJWTIssuer,
+			audience: opts.AuthJWTAudience,
+		}, nil
+	default:
+		return nil, errors.New("mnemosyned: unknown auth mode: " + opts.AuthMode)
+	}
+}
+
+// initAuthUnaryServerInterceptor wraps next with bearer-token verification,
+// rejecting unauthenticated calls with codes.Unauthenticated.
+func initAuthUnaryServerInterceptor(a authenticator, next grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if a == nil {
+			return next(ctx, req, info, handler)
+		}
+		ctx, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return next(ctx, req, info, handler)
+	}
+}
+
+// initAuthStreamServerInterceptor wraps next with bearer-token verification
+// for streaming RPCs.
+func initAuthStreamServerInterceptor(a authenticator, next grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a == nil {
+			return next(srv, ss, info, handler)
+		}
+		ctx, err := a.authenticate(ss.Context())
+		if err != nil {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+		return next(srv, &authenticatedServerStream{ServerStream: ss, ctx: ctx}, info, handler)
+	}
+}
+
+type authenticatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errors.New("missing metadata")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", errors.New("missing authorization metadata")
+	}
+	const prefix = "Bearer "
+	if !strings.HasPrefix(values[0], prefix) {
+		return "", errors.New("authorization metadata must use the Bearer scheme")
+	}
+	return strings.TrimPrefix(values[0], prefix), nil
+}
+
+type sharedSecretAuthenticator struct {
+	secret string
+}
+
+func (a *sharedSecretAuthenticator) authenticate(ctx context.Context) (context.Context, error) {
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return ctx, wrapVerifyErr(err)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(a.secret)) != 1 {
+		return ctx, wrapVerifyErr(errors.New("invalid shared secret"))
+	}
+	return context.WithValue(ctx, claimsContextKey{}, &Claims{}), nil
+}
+
+// jwtAuthenticator verifies RS256- or ES256-signed JWTs against a single
+// configured public key; which signing method is accepted follows from the
+// key's own type, so a deployment picks its algorithm by choosing which kind
+// of key to generate, not through a separate option.
+type jwtAuthenticator struct {
+	key      interface{} // *rsa.PublicKey or *ecdsa.PublicKey
+	issuer   string
+	audience string
+}
+
+func (a *jwtAuthenticator) authenticate(ctx context.Context) (context.Context, error) {
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return ctx, wrapVerifyErr(err)
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.Alg() {
+		case jwt.SigningMethodRS256.Alg():
+			if _, ok := a.key.(*rsa.PublicKey); !ok {
+				return nil, errors.New("token is RS256-signed but the configured key is not an RSA public key")
+			}
+		case jwt.SigningMethodES256.Alg():
+			if _, ok := a.key.(*ecdsa.PublicKey); !ok {
+				return nil, errors.New("token is ES256-signed but the configured key is not an ECDSA public key")
+			}
+		default:
+			return nil, errors.New("unexpected signing method")
+		}
+		return a.key, nil
+	})
+	if err != nil {
+		return ctx, wrapVerifyErr(err)
+	}
+	if a.issuer != "" && !claims.VerifyIssuer(a.issuer, true) {
+		return ctx, wrapVerifyErr(errors.New("unexpected issuer"))
+	}
+	if a.audience != "" && !claims.VerifyAudience(a.audience, true) {
+		return ctx, wrapVerifyErr(errors.New("unexpected audience"))
+	}
+
+	sub, _ := claims["sub"].(string)
+	var scopes []string
+	if s, ok := claims["scope"].(string); ok {
+		scopes = strings.Fields(s)
+	}
+
+	return context.WithValue(ctx, claimsContextKey{}, &Claims{
+		Subject: sub,
+		Scopes:  scopes,
+	}), nil
+}
+
+// loadJWTPublicKey reads the PEM-encoded public key at path, trying RSA
+// before falling back to ECDSA, so the same AuthJWTPublicKeyFile option
+// works for either RS256 or ES256 tokens.
+func loadJWTPublicKey(path string) (interface{}, error) {
+	if path == "" {
+		return nil, errors.New("mnemosyned: AuthJWTPublicKeyFile is required for jwt auth mode")
+	}
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(raw); err == nil {
+		return key, nil
+	}
+	key, err := jwt.ParseECPublicKeyFromPEM(raw)
+	if err != nil {
+		return nil, errors.New("mnemosyned: AuthJWTPublicKeyFile must contain an RSA or ECDSA public key in PEM format")
+	}
+	return key, nil
+}
+
+func wrapVerifyErr(err error) error {
+	return &verifyError{err: err}
+}
+
+// verifyError implements errors.Is against ErrVerifyOnly so callers can
+// distinguish a rejected credential from a transport-level failure.
+type verifyError struct {
+	err error
+}
+
+func (e *verifyError) Error() string { return ErrVerifyOnly.Error() + ": " + e.err.Error() }
+func (e *verifyError) Unwrap() error { return e.err }
+func (e *verifyError) Is(target error) bool {
+	return target == ErrVerifyOnly
+}
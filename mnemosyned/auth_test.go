@@ -0,0 +1,190 @@
+package mnemosyned
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"google.golang.org/grpc/metadata"
+)
+
+func signedToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return signed
+}
+
+func authContext(token string) context.Context {
+	md := metadata.Pairs("authorization", "Bearer "+token)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func testRSAKeyPair(t *testing.T) (*rsa.PrivateKey, *rsa.PublicKey) {
+	t.Helper()
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return priv, &priv.PublicKey
+}
+
+func testECDSAKeyPair(t *testing.T) (*ecdsa.PrivateKey, *ecdsa.PublicKey) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	return priv, &priv.PublicKey
+}
+
+func TestJWTAuthenticator_RS256(t *testing.T) {
+	priv, pub := testRSAKeyPair(t)
+	a := &jwtAuthenticator{key: pub, issuer: "mnemosyned", audience: "mnemosyne-clients"}
+
+	token := signedToken(t, jwt.SigningMethodRS256, priv, jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "mnemosyned",
+		"aud": "mnemosyne-clients",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	ctx, err := a.authenticate(authContext(token))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		t.Fatal("expected claims to be attached to the context")
+	}
+	if claims.Subject != "user-1" {
+		t.Fatalf("expected subject %q but got %q", "user-1", claims.Subject)
+	}
+}
+
+func TestJWTAuthenticator_ES256(t *testing.T) {
+	priv, pub := testECDSAKeyPair(t)
+	a := &jwtAuthenticator{key: pub, issuer: "mnemosyned", audience: "mnemosyne-clients"}
+
+	token := signedToken(t, jwt.SigningMethodES256, priv, jwt.MapClaims{
+		"sub": "user-2",
+		"iss": "mnemosyned",
+		"aud": "mnemosyne-clients",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	ctx, err := a.authenticate(authContext(token))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		t.Fatal("expected claims to be attached to the context")
+	}
+	if claims.Subject != "user-2" {
+		t.Fatalf("expected subject %q but got %q", "user-2", claims.Subject)
+	}
+}
+
+func TestJWTAuthenticator_ExpiredToken(t *testing.T) {
+	priv, pub := testRSAKeyPair(t)
+	a := &jwtAuthenticator{key: pub}
+
+	token := signedToken(t, jwt.SigningMethodRS256, priv, jwt.MapClaims{
+		"sub": "user-1",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	})
+
+	if _, err := a.authenticate(authContext(token)); err == nil {
+		t.Fatal("expected expired token to be rejected")
+	}
+}
+
+func TestJWTAuthenticator_WrongIssuer(t *testing.T) {
+	priv, pub := testRSAKeyPair(t)
+	a := &jwtAuthenticator{key: pub, issuer: "mnemosyned"}
+
+	token := signedToken(t, jwt.SigningMethodRS256, priv, jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "someone-else",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	if _, err := a.authenticate(authContext(token)); err == nil {
+		t.Fatal("expected token with wrong issuer to be rejected")
+	}
+}
+
+func TestJWTAuthenticator_WrongAudience(t *testing.T) {
+	priv, pub := testRSAKeyPair(t)
+	a := &jwtAuthenticator{key: pub, audience: "mnemosyne-clients"}
+
+	token := signedToken(t, jwt.SigningMethodRS256, priv, jwt.MapClaims{
+		"sub": "user-1",
+		"aud": "someone-else",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	if _, err := a.authenticate(authContext(token)); err == nil {
+		t.Fatal("expected token with wrong audience to be rejected")
+	}
+}
+
+func TestJWTAuthenticator_WrongKeyType(t *testing.T) {
+	_, rsaPub := testRSAKeyPair(t)
+	ecPriv, _ := testECDSAKeyPair(t)
+	a := &jwtAuthenticator{key: rsaPub}
+
+	token := signedToken(t, jwt.SigningMethodES256, ecPriv, jwt.MapClaims{
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	if _, err := a.authenticate(authContext(token)); err == nil {
+		t.Fatal("expected token signed with a method incompatible with the configured key to be rejected")
+	}
+}
+
+func TestJWTAuthenticator_MissingMetadata(t *testing.T) {
+	_, pub := testRSAKeyPair(t)
+	a := &jwtAuthenticator{key: pub}
+
+	if _, err := a.authenticate(context.Background()); err == nil {
+		t.Fatal("expected missing authorization metadata to be rejected")
+	}
+}
+
+func TestSharedSecretAuthenticator_Valid(t *testing.T) {
+	a := &sharedSecretAuthenticator{secret: "s3cr3t"}
+
+	if _, err := a.authenticate(authContext("s3cr3t")); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+}
+
+func TestSharedSecretAuthenticator_Invalid(t *testing.T) {
+	a := &sharedSecretAuthenticator{secret: "s3cr3t"}
+
+	if _, err := a.authenticate(authContext("wrong")); err == nil {
+		t.Fatal("expected wrong shared secret to be rejected")
+	}
+}
+
+func TestSharedSecretAuthenticator_MissingMetadata(t *testing.T) {
+	a := &sharedSecretAuthenticator{secret: "s3cr3t"}
+
+	if _, err := a.authenticate(context.Background()); err == nil {
+		t.Fatal("expected missing authorization metadata to be rejected")
+	}
+}
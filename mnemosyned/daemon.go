@@ -1,6 +1,7 @@
 package mnemosyned
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"io"
@@ -9,35 +10,55 @@ import (
 	"net/http/pprof"
 	"net/url"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/boltdb/bolt"
 	"github.com/go-kit/kit/log"
+	"github.com/gomodule/redigo/redis"
+	"github.com/piotrkowalczuk/mnemosyne/internal/cluster"
 	"github.com/piotrkowalczuk/mnemosyne/mnemosynerpc"
 	"github.com/piotrkowalczuk/sklog"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
-	"github.com/boltdb/bolt"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // DaemonOpts it is constructor argument that can be passed to
 // the NewDaemon constructor function.
 type DaemonOpts struct {
-	IsTest          bool
-	SessionTTL      time.Duration
-	SessionTTC      time.Duration
-	Monitoring      bool
-	TLS             bool
-	TLSCertFile     string
-	TLSKeyFile      string
-	Storage         string
-	PostgresAddress string
-	Logger          log.Logger
-	RPCOptions      []grpc.ServerOption
-	RPCListener     net.Listener
-	DebugListener   net.Listener
+	IsTest                 bool
+	SessionTTL             time.Duration
+	SessionTTC             time.Duration
+	Monitoring             bool
+	TLS                    bool
+	TLSCertFile            string
+	TLSKeyFile             string
+	Storage                string
+	PostgresAddress        string
+	RedisAddress           string
+	RedisPassword          string
+	RedisDB                int
+	RedisPoolSize          int
+	Logger                 log.Logger
+	RPCOptions             []grpc.ServerOption
+	RPCListener            net.Listener
+	DebugListener          net.Listener
+	HTTPListener           net.Listener
+	HTTPCORSAllowedOrigins []string
+	ClusterListenAddr      string
+	ClusterSeeds           []string
+	ClusterBindPort        int
+	AuthMode               string
+	AuthJWTPublicKeyFile   string
+	AuthJWTIssuer          string
+	AuthJWTAudience        string
+	AuthSharedSecret       string
+	MinReadyPeers          int
 }
 
 // TestDaemonOpts set of options that are used with TestDaemon instance.
@@ -53,10 +74,16 @@ type Daemon struct {
 	rpcOptions    []grpc.ServerOption
 	postgres      *sql.DB
 	boltdb        *bolt.DB
+	redis         *redis.Pool
 	storage       storage
+	cluster       *cluster.Cluster
 	logger        log.Logger
 	rpcListener   net.Listener
 	debugListener net.Listener
+	httpListener  net.Listener
+	health        *health.Server
+	grpcServer    *grpc.Server
+	closeOnce     sync.Once
 }
 
 // NewDaemon allocates new daemon instance using given options.
@@ -68,6 +95,7 @@ func NewDaemon(opts *DaemonOpts) (*Daemon, error) {
 		rpcOptions:    opts.RPCOptions,
 		rpcListener:   opts.RPCListener,
 		debugListener: opts.DebugListener,
+		httpListener:  opts.HTTPListener,
 	}
 
 	if err := d.setPostgresConnectionParameters(); err != nil {
@@ -130,11 +158,37 @@ func (d *Daemon) Run() (err error) {
 		d.rpcOptions = append(d.rpcOptions, grpc.Creds(creds))
 	}
 
+	if d.opts.ClusterListenAddr != "" {
+		if d.cluster, err = cluster.New(cluster.Opts{
+			Listen:   d.opts.ClusterListenAddr,
+			Seeds:    d.opts.ClusterSeeds,
+			BindPort: d.opts.ClusterBindPort,
+		}); err != nil {
+			return err
+		}
+	}
+
+	auth, err := initAuthenticator(d.opts)
+	if err != nil {
+		return err
+	}
+
+	noopStream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	}
+
 	grpclog.SetLogger(sklog.NewGRPCLogger(d.logger))
-	gRPCServer := grpc.NewServer(append(d.rpcOptions, grpc.UnaryInterceptor(initUnaryServerInterceptor(d.monitor.rpc)))...)
+	d.grpcServer = grpc.NewServer(append(d.rpcOptions,
+		grpc.UnaryInterceptor(initAuthUnaryServerInterceptor(auth, initUnaryServerInterceptor(d.monitor.rpc))),
+		grpc.StreamInterceptor(initAuthStreamServerInterceptor(auth, noopStream)),
+	)...)
+	gRPCServer := d.grpcServer
 	mnemosyneServer := newSessionManager(d.logger, d.storage, d.monitor, d.opts.SessionTTC)
 	mnemosynerpc.RegisterSessionManagerServer(gRPCServer, mnemosyneServer)
 
+	d.health = d.initGRPCHealthServer()
+	healthpb.RegisterHealthServer(gRPCServer, d.health)
+
 	go func() {
 		sklog.Info(d.logger, "rpc server is running", "address", d.rpcListener.Addr().String())
 
@@ -148,6 +202,23 @@ func (d *Daemon) Run() (err error) {
 		}
 	}()
 
+	if d.httpListener != nil {
+		dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+		if d.opts.TLS {
+			creds, err := credentials.NewClientTLSFromFile(d.opts.TLSCertFile, "")
+			if err != nil {
+				return err
+			}
+			dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+		}
+
+		handler, err := d.initGateway(context.Background(), d.rpcListener.Addr().String(), dialOpts...)
+		if err != nil {
+			return err
+		}
+		go runGatewayListener(d.logger, d.httpListener, handler, d.opts.TLSCertFile, d.opts.TLSKeyFile)
+	}
+
 	if d.debugListener != nil {
 		go func() {
 			sklog.Info(d.logger, "debug server is running", "address", d.debugListener.Addr().String())
@@ -160,8 +231,10 @@ func (d *Daemon) Run() (err error) {
 			mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
 			mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
 			mux.Handle("/metrics", prometheus.Handler())
-			mux.Handle("/health", &healthHandler{
-				postgres: d.postgres,
+			mux.Handle("/livez", livezHandler{})
+			mux.Handle("/readyz", &readyzHandler{
+				daemon:        d,
+				minReadyPeers: d.opts.MinReadyPeers,
 			})
 			sklog.Error(d.logger, http.Serve(d.debugListener, mux))
 		}()
@@ -172,15 +245,76 @@ func (d *Daemon) Run() (err error) {
 	return
 }
 
-// Close implements io.Closer interface.
-func (d *Daemon) Close() (err error) {
-	d.done <- struct{}{}
-	if err = d.rpcListener.Close(); err != nil {
-		return
-	}
-	if d.debugListener != nil {
-		err = d.debugListener.Close()
-	}
+// DefaultShutdownTimeout bounds how long Close waits for in-flight RPCs to
+// drain via grpc.Server.GracefulStop before forcing an abrupt Stop.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// Close implements io.Closer interface by calling CloseWithContext bounded by
+// DefaultShutdownTimeout. Callers that need their own drain deadline (the
+// mnemosyned command translating a SIGTERM, for instance) should call
+// CloseWithContext directly instead.
+func (d *Daemon) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
+	defer cancel()
+	return d.CloseWithContext(ctx)
+}
+
+// CloseWithContext drains in-flight RPCs via GracefulStop, falling back to an
+// immediate Stop if ctx is done first, and only closes storage handles and
+// auxiliary listeners once the gRPC server has actually returned from Serve -
+// this is what previously let the cleanup goroutine panic on a send to an
+// already-closed channel and left in-flight RPCs with a broken connection.
+func (d *Daemon) CloseWithContext(ctx context.Context) (err error) {
+	d.closeOnce.Do(func() {
+		if d.health != nil {
+			d.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		}
+		close(d.done)
+
+		stopped := make(chan struct{})
+		go func() {
+			d.grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			d.grpcServer.Stop()
+			<-stopped
+		}
+
+		if d.debugListener != nil {
+			if cerr := d.debugListener.Close(); cerr != nil {
+				err = cerr
+			}
+		}
+		if d.httpListener != nil {
+			if cerr := d.httpListener.Close(); cerr != nil {
+				err = cerr
+			}
+		}
+		if d.cluster != nil {
+			if cerr := d.cluster.Shutdown(); cerr != nil {
+				err = cerr
+			}
+		}
+		if d.postgres != nil {
+			if cerr := d.postgres.Close(); cerr != nil {
+				err = cerr
+			}
+		}
+		if d.boltdb != nil {
+			if cerr := d.boltdb.Close(); cerr != nil {
+				err = cerr
+			}
+		}
+		if d.redis != nil {
+			if cerr := d.redis.Close(); cerr != nil {
+				err = cerr
+			}
+		}
+	})
 	return
 }
 
@@ -206,14 +340,27 @@ func (d *Daemon) initStorage() (err error) {
 			return err
 		}
 	case StorageEngineRedis:
-		return errors.New("redis storage is not implemented yet")
+		d.redis = initRedis(
+			d.opts.RedisAddress,
+			d.opts.RedisPassword,
+			d.opts.RedisDB,
+			d.opts.RedisPoolSize,
+		)
 	default:
 		return errors.New("unknown storage engine")
 	}
 
+	var engine storage
+	switch d.opts.Storage {
+	case StorageEngineRedis:
+		engine = newRedisStorage("session", d.redis, d.opts.SessionTTL)
+	default:
+		engine = newPostgresStorage("session", d.postgres, d.monitor, d.opts.SessionTTL)
+	}
+
 	if d.storage, err = initStorage(
 		d.opts.IsTest,
-		newPostgresStorage("session", d.postgres, d.monitor, d.opts.SessionTTL),
+		engine,
 		d.logger,
 	); err != nil {
 		return
@@ -221,6 +368,31 @@ func (d *Daemon) initStorage() (err error) {
 	return
 }
 
+// initRedis allocates a connection pool to the redis instance backing the
+// StorageEngineRedis storage. Connections are created lazily and tested with
+// PING before being handed out.
+func initRedis(address, password string, db, poolSize int) *redis.Pool {
+	if poolSize <= 0 {
+		poolSize = 10
+	}
+
+	return &redis.Pool{
+		MaxIdle:     poolSize,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			opts := []redis.DialOption{redis.DialDatabase(db)}
+			if password != "" {
+				opts = append(opts, redis.DialPassword(password))
+			}
+			return redis.Dial("tcp", address, opts...)
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			_, err := c.Do("PING")
+			return err
+		},
+	}
+}
+
 func (d *Daemon) setPostgresConnectionParameters() error {
 	u, err := url.Parse(d.opts.PostgresAddress)
 	if err != nil {
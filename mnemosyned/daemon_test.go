@@ -97,6 +97,56 @@ func TestDaemon_Run(t *testing.T) {
 	}
 }
 
+// TestDaemon_CloseDrainsInFlightRPCs asserts that a Get in flight when Close
+// is called still completes successfully, instead of failing on a connection
+// torn down mid-RPC.
+func TestDaemon_CloseDrainsInFlightRPCs(t *testing.T) {
+	rl := listener(t)
+
+	d, err := NewDaemon(&DaemonOpts{
+		IsTest:          true,
+		Logger:          zap.L(),
+		RPCListener:     rl,
+		PostgresAddress: testPostgresAddress,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := d.Run(); err != nil {
+		t.Fatalf("mnemosyne daemon start error: %s", err.Error())
+	}
+
+	conn, m := connect(t, rl)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	started, err := m.Start(ctx, &mnemosynerpc.StartRequest{
+		Session: &mnemosynerpc.Session{SubjectId: "1", SubjectClient: "daemon close test"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		getCtx, getCancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer getCancel()
+		_, err := m.Get(getCtx, &mnemosynerpc.GetRequest{AccessToken: started.Session.AccessToken})
+		errs <- err
+	}()
+
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer closeCancel()
+	if err := d.CloseWithContext(closeCtx); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := <-errs; err != nil {
+		t.Fatalf("in-flight Get should have completed successfully, got: %s", err.Error())
+	}
+}
+
 func TestTestDaemon(t *testing.T) {
 	addr, closer := TestDaemon(t, TestDaemonOpts{
 		StoragePostgresAddress: testPostgresAddress,
@@ -312,6 +362,103 @@ func TestDaemon_Cluster(t *testing.T) {
 	})
 }
 
+// TestDaemon_ClusterGossip extends TestDaemon_Cluster by turning on gossip
+// based membership (ClusterBindPort) across three daemons, killing one mid-test,
+// and asserting the remaining two converge on a two-node ring within a bounded
+// timeout instead of continuing to route to the dead peer.
+func TestDaemon_ClusterGossip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test takes too long to run it in short mode")
+	}
+
+	cfg := zap.NewDevelopmentConfig()
+	cfg.Level.SetLevel(zap.WarnLevel)
+	l, _ := cfg.Build()
+
+	l1, l2, l3 := listener(t), listener(t), listener(t)
+	p1, p2, p3 := freePort(t), freePort(t), freePort(t)
+
+	seeds := []string{
+		loopbackAddr(p1),
+		loopbackAddr(p2),
+		loopbackAddr(p3),
+	}
+
+	newOpts := func(rl net.Listener, port int) *DaemonOpts {
+		return &DaemonOpts{
+			IsTest:            true,
+			RPCListener:       rl,
+			Logger:            l,
+			PostgresAddress:   testPostgresAddress,
+			ClusterListenAddr: rl.Addr().String(),
+			ClusterBindPort:   port,
+			ClusterSeeds:      seeds,
+		}
+	}
+
+	d1, err := NewDaemon(newOpts(l1, p1))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := d1.Run(); err != nil {
+		t.Fatalf("mnemosyne daemon 1 start error: %s", err.Error())
+	}
+	defer d1.Close()
+
+	d2, err := NewDaemon(newOpts(l2, p2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := d2.Run(); err != nil {
+		t.Fatalf("mnemosyne daemon 2 start error: %s", err.Error())
+	}
+	defer d2.Close()
+
+	d3, err := NewDaemon(newOpts(l3, p3))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := d3.Run(); err != nil {
+		t.Fatalf("mnemosyne daemon 3 start error: %s", err.Error())
+	}
+
+	// give gossip a moment to converge on the initial 3-node membership.
+	deadline := time.Now().Add(10 * time.Second)
+	for d1.cluster.Len() != 3 || d2.cluster.Len() != 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("cluster did not converge on 3 nodes in time: d1=%d d2=%d", d1.cluster.Len(), d2.cluster.Len())
+		}
+		<-time.After(100 * time.Millisecond)
+	}
+
+	if err := d3.Close(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	deadline = time.Now().Add(15 * time.Second)
+	for d1.cluster.Len() != 2 || d2.cluster.Len() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("cluster did not reshard after a node left: d1=%d d2=%d", d1.cluster.Len(), d2.cluster.Len())
+		}
+		<-time.After(100 * time.Millisecond)
+	}
+}
+
+func freePort(t testing.TB) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func loopbackAddr(port int) string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+}
+
 func listener(t testing.TB) net.Listener {
 	t.Helper()
 
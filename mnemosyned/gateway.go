@@ -0,0 +1,66 @@
+package mnemosyned
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/go-kit/kit/log"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/piotrkowalczuk/mnemosyne/mnemosynerpc"
+	"github.com/piotrkowalczuk/sklog"
+	"google.golang.org/grpc"
+)
+
+// initGateway dials back into the in-process gRPC server and registers a
+// runtime.ServeMux that exposes mnemosynerpc.SessionManager as a REST/JSON
+// API, so that non-gRPC clients can call Start, Get, Exists, SetValue and
+// Delete over plain HTTP.
+func (d *Daemon) initGateway(ctx context.Context, rpcAddr string, dialOpts ...grpc.DialOption) (http.Handler, error) {
+	mux := runtime.NewServeMux()
+	if err := mnemosynerpc.RegisterSessionManagerHandlerFromEndpoint(ctx, mux, rpcAddr, dialOpts); err != nil {
+		return nil, err
+	}
+
+	return withCORS(mux, d.opts.HTTPCORSAllowedOrigins), nil
+}
+
+// withCORS wraps h with CORS handling that is deny-by-default: this API
+// carries bearer tokens and session bags, so cross-origin requests are only
+// allowed once allowedOrigins is explicitly configured with the origins that
+// should be able to reach it.
+func withCORS(h http.Handler, allowedOrigins []string) http.Handler {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, o := range allowedOrigins {
+		allowed[o] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && allowed[origin] {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		}
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func runGatewayListener(logger log.Logger, l net.Listener, handler http.Handler, tlsCertFile, tlsKeyFile string) {
+	sklog.Info(logger, "gateway server is running", "address", l.Addr().String())
+
+	server := &http.Server{Handler: handler}
+	var err error
+	if tlsCertFile != "" && tlsKeyFile != "" {
+		err = server.ServeTLS(l, tlsCertFile, tlsKeyFile)
+	} else {
+		err = server.Serve(l)
+	}
+	if err != nil && err != http.ErrServerClosed {
+		sklog.Error(logger, err)
+	}
+}
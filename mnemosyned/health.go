@@ -0,0 +1,120 @@
+package mnemosyned
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// readinessCheckTimeout bounds how long a single readiness probe (storage
+// ping, cluster quorum check) is allowed to take before /readyz gives up and
+// reports not-ready.
+const readinessCheckTimeout = 2 * time.Second
+
+// livezHandler reports that the process is alive and able to serve HTTP at
+// all; it never depends on storage or cluster state.
+type livezHandler struct{}
+
+func (livezHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+// readyzHandler reports whether the daemon is ready to serve traffic: its
+// storage backend must be reachable and, when clustering is enabled, the
+// cluster must have at least minReadyPeers live members.
+type readyzHandler struct {
+	daemon        *Daemon
+	minReadyPeers int
+}
+
+func (h *readyzHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+	defer cancel()
+
+	if err := h.checkStorage(ctx); err != nil {
+		http.Error(w, "storage not reachable: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err := h.checkRegistry(); err != nil {
+		http.Error(w, "metrics registry not initialized: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err := h.checkClusterQuorum(); err != nil {
+		http.Error(w, "cluster quorum not met: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+func (h *readyzHandler) checkStorage(ctx context.Context) error {
+	d := h.daemon
+	switch d.opts.Storage {
+	case StorageEnginePostgres:
+		if d.postgres == nil {
+			return errStorageNotInitialized
+		}
+		return d.postgres.PingContext(ctx)
+	case StorageEngineRedis:
+		if d.redis == nil {
+			return errStorageNotInitialized
+		}
+		conn, err := d.redis.GetContext(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, err = redis.String(conn.Do("PING"))
+		return err
+	case StorageEngineInMemory:
+		if d.boltdb == nil {
+			return errStorageNotInitialized
+		}
+		return nil
+	default:
+		return errStorageNotInitialized
+	}
+}
+
+func (h *readyzHandler) checkRegistry() error {
+	if _, err := prometheus.DefaultGatherer.Gather(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (h *readyzHandler) checkClusterQuorum() error {
+	if h.daemon.cluster == nil || h.minReadyPeers <= 0 {
+		return nil
+	}
+	if n := h.daemon.cluster.Len(); n < h.minReadyPeers {
+		return errClusterQuorumNotMet
+	}
+	return nil
+}
+
+var (
+	errStorageNotInitialized = &healthError{"storage is not initialized"}
+	errClusterQuorumNotMet   = &healthError{"not enough live cluster peers"}
+)
+
+type healthError struct{ msg string }
+
+func (e *healthError) Error() string { return e.msg }
+
+// initGRPCHealthServer creates the standard grpc.health.v1.Health service,
+// reporting SERVING for the whole server; Run registers it on the gRPC server
+// so Kubernetes and gRPC-aware load balancers can probe liveness without
+// needing the debug HTTP listener.
+func (d *Daemon) initGRPCHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	return hs
+}
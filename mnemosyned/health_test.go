@@ -0,0 +1,85 @@
+package mnemosyned
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/piotrkowalczuk/mnemosyne/internal/cluster"
+)
+
+func TestLivezHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	livezHandler{}.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected %d but got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestReadyzHandler_PostgresOutage(t *testing.T) {
+	d := &Daemon{
+		opts: &DaemonOpts{Storage: StorageEnginePostgres},
+	}
+	h := &readyzHandler{daemon: d}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected %d but got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+
+	// /livez must stay unaffected by the storage outage that /readyz surfaces.
+	liveRec := httptest.NewRecorder()
+	livezHandler{}.ServeHTTP(liveRec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if liveRec.Code != http.StatusOK {
+		t.Fatalf("expected %d but got %d", http.StatusOK, liveRec.Code)
+	}
+}
+
+func TestReadyzHandler_ClusterQuorumNotMet(t *testing.T) {
+	dbFile, err := os.CreateTemp("", "mnemosyned-readyz-*.db")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	dbFile.Close()
+	defer os.Remove(dbFile.Name())
+
+	boltdb, err := bolt.Open(dbFile.Name(), 0600, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	defer boltdb.Close()
+
+	c, err := cluster.New(cluster.Opts{Listen: "127.0.0.1:1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if got := c.Len(); got != 1 {
+		t.Fatalf("expected single-node cluster but got %d nodes", got)
+	}
+
+	d := &Daemon{
+		opts:    &DaemonOpts{Storage: StorageEngineInMemory},
+		boltdb:  boltdb,
+		cluster: c,
+	}
+	h := &readyzHandler{daemon: d, minReadyPeers: 2}
+
+	// Storage is healthy (boltdb is open) and the cluster is real, but it only
+	// has 1 member against a minReadyPeers of 2, so checkClusterQuorum must be
+	// the check that fails here, not checkStorage.
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected %d but got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "cluster quorum") {
+		t.Fatalf("expected response to mention cluster quorum, got %q", body)
+	}
+}
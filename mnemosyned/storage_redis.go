@@ -0,0 +1,202 @@
+package mnemosyned
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/gomodule/redigo/redis"
+	"github.com/piotrkowalczuk/mnemosyne/mnemosynerpc"
+)
+
+const (
+	redisSessionField       = "session"
+	redisBagFieldPrefix     = "bag:"
+	redisSubjectIDField     = "subject_id"
+	redisSubjectClientField = "subject_client"
+)
+
+// redisStorage is a storage implementation that keeps every session as a single
+// Redis hash keyed by access token. The hash TTL is refreshed on every write so
+// that Redis itself expires abandoned sessions - no background cleanup goroutine
+// is required for this backend.
+type redisStorage struct {
+	pool      *redis.Pool
+	ttl       time.Duration
+	namespace string
+	logger    log.Logger
+}
+
+// newRedisStorage allocates new redisStorage instance. It does not take a
+// *monitoring: this backend has no per-call metrics to report yet, so
+// carrying one around unused would just be dead state.
+func newRedisStorage(namespace string, pool *redis.Pool, ttl time.Duration) storage {
+	return &redisStorage{
+		namespace: namespace,
+		pool:      pool,
+		ttl:       ttl,
+	}
+}
+
+func (rs *redisStorage) key(accessToken string) string {
+	return fmt.Sprintf("%s:session:%s", rs.namespace, accessToken)
+}
+
+func (rs *redisStorage) Start(ctx context.Context, subjectID, subjectClient string, bag map[string]string) (*mnemosynerpc.Session, error) {
+	conn := rs.pool.Get()
+	defer conn.Close()
+
+	at, err := newAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expireAt := now.Add(rs.ttl)
+
+	args := redis.Args{}.Add(rs.key(at)).
+		Add(redisSubjectIDField, subjectID).
+		Add(redisSubjectClientField, subjectClient)
+	for k, v := range bag {
+		args = args.Add(redisBagFieldPrefix+k, v)
+	}
+
+	conn.Send("MULTI")
+	conn.Send("HSET", args...)
+	conn.Send("EXPIRE", rs.key(at), int64(rs.ttl.Seconds()))
+	if _, err := conn.Do("EXEC"); err != nil {
+		return nil, err
+	}
+
+	session := &mnemosynerpc.Session{
+		AccessToken:   at,
+		SubjectId:     subjectID,
+		SubjectClient: subjectClient,
+		Bag:           bag,
+	}
+	session.ExpireAt, _ = ptypes.TimestampProto(expireAt)
+	return session, nil
+}
+
+func (rs *redisStorage) Get(ctx context.Context, accessToken string) (*mnemosynerpc.Session, error) {
+	conn := rs.pool.Get()
+	defer conn.Close()
+
+	values, err := redis.StringMap(conn.Do("HGETALL", rs.key(accessToken)))
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, errSessionNotFound
+	}
+
+	ttl, err := redis.Int64(conn.Do("TTL", rs.key(accessToken)))
+	if err != nil {
+		return nil, err
+	}
+
+	session := &mnemosynerpc.Session{
+		AccessToken:   accessToken,
+		SubjectId:     values[redisSubjectIDField],
+		SubjectClient: values[redisSubjectClientField],
+		Bag:           map[string]string{},
+	}
+	session.ExpireAt, _ = ptypes.TimestampProto(time.Now().Add(time.Duration(ttl) * time.Second))
+	for k, v := range values {
+		if len(k) > len(redisBagFieldPrefix) && k[:len(redisBagFieldPrefix)] == redisBagFieldPrefix {
+			session.Bag[k[len(redisBagFieldPrefix):]] = v
+		}
+	}
+	return session, nil
+}
+
+func (rs *redisStorage) Exists(ctx context.Context, accessToken string) (bool, error) {
+	conn := rs.pool.Get()
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("EXISTS", rs.key(accessToken)))
+}
+
+// SetValue sets a single bag entry atomically and refreshes the session TTL so the write
+// cannot outlive the hash it belongs to.
+func (rs *redisStorage) SetValue(ctx context.Context, accessToken, key, value string) (map[string]string, error) {
+	conn := rs.pool.Get()
+	defer conn.Close()
+
+	exists, err := redis.Bool(conn.Do("EXISTS", rs.key(accessToken)))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errSessionNotFound
+	}
+
+	conn.Send("MULTI")
+	conn.Send("HSET", rs.key(accessToken), redisBagFieldPrefix+key, value)
+	conn.Send("EXPIRE", rs.key(accessToken), int64(rs.ttl.Seconds()))
+	if _, err := conn.Do("EXEC"); err != nil {
+		return nil, err
+	}
+
+	values, err := redis.StringMap(conn.Do("HGETALL", rs.key(accessToken)))
+	if err != nil {
+		return nil, err
+	}
+	bag := make(map[string]string)
+	for k, v := range values {
+		if len(k) > len(redisBagFieldPrefix) && k[:len(redisBagFieldPrefix)] == redisBagFieldPrefix {
+			bag[k[len(redisBagFieldPrefix):]] = v
+		}
+	}
+	return bag, nil
+}
+
+func (rs *redisStorage) Delete(ctx context.Context, accessToken string) (int64, error) {
+	conn := rs.pool.Get()
+	defer conn.Close()
+
+	if accessToken != "" {
+		n, err := redis.Int64(conn.Do("DEL", rs.key(accessToken)))
+		return n, err
+	}
+
+	return rs.deleteMatching(conn, rs.namespace+":session:*")
+}
+
+// deleteMatching removes every session key matching pattern using SCAN so that a full
+// KEYS scan never blocks the Redis event loop on large datasets.
+func (rs *redisStorage) deleteMatching(conn redis.Conn, pattern string) (int64, error) {
+	var (
+		cursor int64
+		total  int64
+	)
+	for {
+		reply, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", pattern, "COUNT", 100))
+		if err != nil {
+			return total, err
+		}
+		if _, err := redis.Scan(reply, &cursor); err != nil {
+			return total, err
+		}
+		keys, err := redis.Strings(reply[1], nil)
+		if err != nil {
+			return total, err
+		}
+		if len(keys) > 0 {
+			args := redis.Args{}.AddFlat(keys)
+			n, err := redis.Int64(conn.Do("DEL", args...))
+			if err != nil {
+				return total, err
+			}
+			total += n
+		}
+		if cursor == 0 {
+			break
+		}
+	}
+	return total, nil
+}
+
+var errSessionNotFound = errors.New("mnemosyned: session not found")
@@ -0,0 +1,188 @@
+package mnemosyned
+
+import (
+	"context"
+	"flag"
+	"os"
+	"testing"
+	"time"
+)
+
+var testRedisAddress string
+
+func init() {
+	flag.StringVar(&testRedisAddress, "redis.address", getStringEnvOr("MNEMOSYNED_REDIS_ADDRESS", "127.0.0.1:6379"), "")
+}
+
+func getStringEnvOr(env, or string) string {
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	return or
+}
+
+func testRedisStorage(t *testing.T) storage {
+	t.Helper()
+
+	pool := initRedis(testRedisAddress, "", 0, 2)
+	conn := pool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("PING"); err != nil {
+		t.Skipf("redis is not available: %s", err.Error())
+	}
+
+	return newRedisStorage("session_test", pool, 2*time.Second)
+}
+
+func TestRedisStorage_Start(t *testing.T) {
+	s := testRedisStorage(t)
+
+	session, err := s.Start(context.Background(), "1", "redis test client", map[string]string{"key": "value"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if session.AccessToken == "" {
+		t.Fatal("access token should not be empty")
+	}
+}
+
+func TestRedisStorage_Get(t *testing.T) {
+	s := testRedisStorage(t)
+
+	started, err := s.Start(context.Background(), "1", "redis test client", map[string]string{"key": "value"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	got, err := s.Get(context.Background(), started.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if got.Bag["key"] != "value" {
+		t.Fatalf("wrong bag value, expected %q but got %q", "value", got.Bag["key"])
+	}
+}
+
+func TestRedisStorage_Exists(t *testing.T) {
+	s := testRedisStorage(t)
+
+	started, err := s.Start(context.Background(), "1", "redis test client", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	ok, err := s.Exists(context.Background(), started.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !ok {
+		t.Fatal("session should exist")
+	}
+
+	ok, err = s.Exists(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if ok {
+		t.Fatal("session should not exist")
+	}
+}
+
+func TestRedisStorage_SetValue(t *testing.T) {
+	s := testRedisStorage(t)
+
+	started, err := s.Start(context.Background(), "1", "redis test client", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	bag, err := s.SetValue(context.Background(), started.AccessToken, "key", "value")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if bag["key"] != "value" {
+		t.Fatalf("wrong bag value, expected %q but got %q", "value", bag["key"])
+	}
+}
+
+func TestRedisStorage_Delete(t *testing.T) {
+	s := testRedisStorage(t)
+
+	started, err := s.Start(context.Background(), "1", "redis test client", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	n, err := s.Delete(context.Background(), started.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if n != 1 {
+		t.Fatalf("expected to delete 1 session but deleted %d", n)
+	}
+
+	ok, err := s.Exists(context.Background(), started.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if ok {
+		t.Fatal("session should have been deleted")
+	}
+}
+
+func TestRedisStorage_DeleteMatching(t *testing.T) {
+	s := testRedisStorage(t)
+
+	// sessions left alive by earlier tests share the namespace, so clear it
+	// first to make the count below cover only the sessions started here.
+	if _, err := s.Delete(context.Background(), ""); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var started []string
+	for i := 0; i < 3; i++ {
+		session, err := s.Start(context.Background(), "1", "redis test client", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		started = append(started, session.AccessToken)
+	}
+
+	n, err := s.Delete(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if n != int64(len(started)) {
+		t.Fatalf("expected to delete %d sessions but deleted %d", len(started), n)
+	}
+
+	for _, at := range started {
+		ok, err := s.Exists(context.Background(), at)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if ok {
+			t.Fatalf("session %s should have been deleted", at)
+		}
+	}
+}
+
+func TestRedisStorage_TTL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test takes too long to run it in short mode")
+	}
+
+	s := testRedisStorage(t)
+
+	started, err := s.Start(context.Background(), "1", "redis test client", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	<-time.After(3 * time.Second)
+
+	_, err = s.Get(context.Background(), started.AccessToken)
+	if err == nil {
+		t.Fatal("session should have expired")
+	}
+}